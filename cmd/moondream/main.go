@@ -10,23 +10,68 @@ import (
     "time"
 )
 
+// parseStreamFlag pulls a "-stream" flag out of args, returning the
+// remaining positional arguments alongside whether it was present.
+func parseStreamFlag(args []string) ([]string, bool) {
+    remaining := make([]string, 0, len(args))
+    stream := false
+    for _, arg := range args {
+        if arg == "-stream" {
+            stream = true
+            continue
+        }
+        remaining = append(remaining, arg)
+    }
+    return remaining, stream
+}
+
+// parseLocalFlag pulls a "-local <model-path>" flag out of args, returning
+// the remaining positional arguments alongside the model path (empty if
+// the flag wasn't present).
+func parseLocalFlag(args []string) ([]string, string) {
+    remaining := make([]string, 0, len(args))
+    modelPath := ""
+    for i := 0; i < len(args); i++ {
+        if args[i] == "-local" && i+1 < len(args) {
+            modelPath = args[i+1]
+            i++
+            continue
+        }
+        remaining = append(remaining, args[i])
+    }
+    return remaining, modelPath
+}
+
 func main() {
     if len(os.Args) < 3 {
         log.Fatal("Usage: moondream <function> <image-path> [options]\n" +
             "Functions: caption, query, detect, point\n" +
+            "Options: -stream, -local <model-path>\n" +
             "Example: moondream caption image.jpg")
     }
 
+    function := os.Args[1]
+    imagePath := os.Args[2]
+
+    rest, modelPath := parseLocalFlag(os.Args[3:])
+    rest, stream := parseStreamFlag(rest)
+
     apiKey := os.Getenv("mdAPI")
-    if apiKey == "" {
-        log.Fatal("Error: mdAPI environment variable not set")
+    if apiKey == "" && modelPath == "" {
+        log.Fatal("Error: mdAPI environment variable not set (or pass -local <model-path> to use the local backend)")
+    }
+
+    clientOpts := []moondream.ClientOption{moondream.WithTimeout(30 * time.Second)}
+    if modelPath != "" {
+        backend, err := moondream.NewLocalBackend(modelPath)
+        if err != nil {
+            log.Fatalf("Error loading local model: %v", err)
+        }
+        clientOpts = append(clientOpts, moondream.WithBackend(backend))
     }
 
     // Create client with options
-    client := moondream.NewMoondreamClient(
-        apiKey,
-        moondream.WithTimeout(30*time.Second),
-    )
+    client := moondream.NewMoondreamClient(apiKey, clientOpts...)
 
     // Create context with cancellation
     ctx, cancel := context.WithCancel(context.Background())
@@ -41,22 +86,43 @@ func main() {
         cancel()
     }()
 
-    function := os.Args[1]
-    imagePath := os.Args[2]
-
     switch function {
     case "caption":
-        caption, err := client.Caption(ctx, imagePath, "long")
+        if stream {
+            chunks, err := client.CaptionStream(ctx, imagePath, "long")
+            if err != nil {
+                log.Fatalf("Error generating caption: %v", err)
+            }
+            fmt.Print("Caption: ")
+            for chunk := range chunks {
+                fmt.Print(chunk.Chunk)
+            }
+            fmt.Println()
+            break
+        }
+        caption, err := client.Caption(ctx, imagePath, "long", false)
         if err != nil {
             log.Fatalf("Error generating caption: %v", err)
         }
         fmt.Println("Caption:", caption)
 
     case "query":
-        if len(os.Args) < 4 {
-            log.Fatal("Usage: moondream query <image-path> <question>")
+        if len(rest) < 1 {
+            log.Fatal("Usage: moondream query <image-path> <question> [-stream] [-local <model-path>]")
+        }
+        question := rest[0]
+        if stream {
+            chunks, err := client.QueryStream(ctx, imagePath, question)
+            if err != nil {
+                log.Fatalf("Error querying image: %v", err)
+            }
+            fmt.Print("Answer: ")
+            for chunk := range chunks {
+                fmt.Print(chunk.Chunk)
+            }
+            fmt.Println()
+            break
         }
-        question := os.Args[3]
         answer, err := client.Query(ctx, imagePath, question)
         if err != nil {
             log.Fatalf("Error querying image: %v", err)
@@ -64,10 +130,10 @@ func main() {
         fmt.Println("Answer:", answer)
 
     case "detect":
-        if len(os.Args) < 4 {
-            log.Fatal("Usage: moondream detect <image-path> <object>")
+        if len(rest) < 1 {
+            log.Fatal("Usage: moondream detect <image-path> <object> [-local <model-path>]")
         }
-        object := os.Args[3]
+        object := rest[0]
         boundingBoxes, err := client.Detect(ctx, imagePath, object)
         if err != nil {
             log.Fatalf("Error detecting objects: %v", err)
@@ -75,21 +141,21 @@ func main() {
         fmt.Printf("Found %d instances of '%s':\n", len(boundingBoxes), object)
         for i, box := range boundingBoxes {
             fmt.Printf("  %d: x_min=%.2f, y_min=%.2f, x_max=%.2f, y_max=%.2f\n",
-                i+1, box["x_min"], box["y_min"], box["x_max"], box["y_max"])
+                i+1, box.XMin, box.YMin, box.XMax, box.YMax)
         }
 
     case "point":
-        if len(os.Args) < 4 {
-            log.Fatal("Usage: moondream point <image-path> <object>")
+        if len(rest) < 1 {
+            log.Fatal("Usage: moondream point <image-path> <object> [-local <model-path>]")
         }
-        object := os.Args[3]
+        object := rest[0]
         points, err := client.Point(ctx, imagePath, object)
         if err != nil {
             log.Fatalf("Error pointing at objects: %v", err)
         }
         fmt.Printf("Found %d points for '%s':\n", len(points), object)
         for i, point := range points {
-            fmt.Printf("  %d: x=%.2f, y=%.2f\n", i+1, point["x"], point["y"])
+            fmt.Printf("  %d: x=%.2f, y=%.2f\n", i+1, point.X, point.Y)
         }
 
     default:
@@ -0,0 +1,85 @@
+package moondream
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backend performs the inference behind MoondreamClient's public methods.
+// HTTPBackend (the default) calls the hosted Moondream API; LocalBackend
+// loads a model file in-process but returns an error from every method.
+// Install a custom Backend with WithBackend.
+type Backend interface {
+	Caption(ctx context.Context, source ImageSource, length string, stream bool) (string, error)
+	Query(ctx context.Context, source ImageSource, question string) (string, error)
+	Detect(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error)
+	Point(ctx context.Context, source ImageSource, object string) ([]Point, error)
+	CaptionStream(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error)
+	QueryStream(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error)
+}
+
+// HTTPBackend is the default Backend, calling the hosted Moondream API
+// through its owning MoondreamClient.
+type HTTPBackend struct {
+	client *MoondreamClient
+}
+
+func (b *HTTPBackend) Caption(ctx context.Context, source ImageSource, length string, stream bool) (string, error) {
+	if stream {
+		return "", fmt.Errorf("Caption does not support stream=true; use CaptionStream or CaptionStreamImage instead")
+	}
+
+	req := CaptionRequest{
+		Image:  source.encoded(),
+		Length: length,
+	}
+
+	var resp CaptionResponse
+	if err := b.client.sendRequest(ctx, "/caption", req, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Caption, nil
+}
+
+func (b *HTTPBackend) Query(ctx context.Context, source ImageSource, question string) (string, error) {
+	req := QueryRequest{
+		Image:    source.encoded(),
+		Question: question,
+	}
+
+	var resp QueryResponse
+	if err := b.client.sendRequest(ctx, "/query", req, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Answer, nil
+}
+
+func (b *HTTPBackend) Detect(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error) {
+	req := DetectRequest{
+		Image:  source.encoded(),
+		Object: object,
+	}
+
+	var resp DetectResponse
+	if err := b.client.sendRequest(ctx, "/detect", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.BoundingBoxes, nil
+}
+
+func (b *HTTPBackend) Point(ctx context.Context, source ImageSource, object string) ([]Point, error) {
+	req := PointRequest{
+		Image:  source.encoded(),
+		Object: object,
+	}
+
+	var resp PointResponse
+	if err := b.client.sendRequest(ctx, "/point", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Points, nil
+}
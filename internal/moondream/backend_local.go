@@ -0,0 +1,66 @@
+//go:build local
+
+package moondream
+
+import (
+	"context"
+	"fmt"
+
+	llama "github.com/go-skynet/go-llama.cpp"
+)
+
+// LocalBackend loads a local GGUF model file in-process via cgo bindings to
+// llama.cpp. It runs no inference: Caption, Query, Detect, Point and the
+// streaming methods all return an error. Build with `-tags local` (and a C
+// toolchain capable of linking llama.cpp) to include it; the default,
+// pure-Go build uses the stub in backend_local_stub.go instead.
+type LocalBackend struct {
+	model *llama.LLama
+}
+
+// NewLocalBackend loads a Moondream GGUF model file for in-process
+// inference.
+func NewLocalBackend(modelPath string) (*LocalBackend, error) {
+	model, err := llama.New(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load local model %q: %w", modelPath, err)
+	}
+	return &LocalBackend{model: model}, nil
+}
+
+// Caption is not yet supported locally - llama.cpp's text-only Predict API
+// has no vision projector to ground the prompt in source, so there is no
+// honest way to produce a caption of the image rather than of the prompt
+// text alone.
+func (b *LocalBackend) Caption(ctx context.Context, source ImageSource, length string, stream bool) (string, error) {
+	return "", fmt.Errorf("local backend does not support Caption yet")
+}
+
+// Query is not yet supported locally for the same reason as Caption.
+func (b *LocalBackend) Query(ctx context.Context, source ImageSource, question string) (string, error) {
+	return "", fmt.Errorf("local backend does not support Query yet")
+}
+
+// Detect is not yet supported locally - doing so needs the same vision
+// projector the hosted API uses to ground boxes in image coordinates,
+// which isn't wired up to llama.cpp's text-only Predict API.
+func (b *LocalBackend) Detect(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error) {
+	return nil, fmt.Errorf("local backend does not support Detect yet")
+}
+
+// Point is not yet supported locally for the same reason as Detect.
+func (b *LocalBackend) Point(ctx context.Context, source ImageSource, object string) ([]Point, error) {
+	return nil, fmt.Errorf("local backend does not support Point yet")
+}
+
+// CaptionStream is not yet supported locally - the llama.cpp binding used
+// here only exposes a blocking Predict call, not a token-by-token callback.
+func (b *LocalBackend) CaptionStream(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error) {
+	return nil, fmt.Errorf("local backend does not support streaming yet")
+}
+
+// QueryStream is not yet supported locally for the same reason as
+// CaptionStream.
+func (b *LocalBackend) QueryStream(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error) {
+	return nil, fmt.Errorf("local backend does not support streaming yet")
+}
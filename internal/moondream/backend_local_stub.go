@@ -0,0 +1,48 @@
+//go:build !local
+
+package moondream
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLocalBackendUnavailable is returned by NewLocalBackend when the binary
+// wasn't built with the `local` build tag, so no llama.cpp bindings are
+// linked in.
+var ErrLocalBackendUnavailable = errors.New("local backend support requires building with -tags local")
+
+// LocalBackend is a non-functional placeholder in the default, pure-Go
+// build. Build with `-tags local` to get the real in-process backend
+// defined in backend_local.go.
+type LocalBackend struct{}
+
+// NewLocalBackend always fails in the default build; see
+// ErrLocalBackendUnavailable.
+func NewLocalBackend(modelPath string) (*LocalBackend, error) {
+	return nil, ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) Caption(ctx context.Context, source ImageSource, length string, stream bool) (string, error) {
+	return "", ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) Query(ctx context.Context, source ImageSource, question string) (string, error) {
+	return "", ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) Detect(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error) {
+	return nil, ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) Point(ctx context.Context, source ImageSource, object string) ([]Point, error) {
+	return nil, ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) CaptionStream(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error) {
+	return nil, ErrLocalBackendUnavailable
+}
+
+func (b *LocalBackend) QueryStream(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error) {
+	return nil, ErrLocalBackendUnavailable
+}
@@ -0,0 +1,83 @@
+package moondream
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewLocalBackendUnavailableByDefault(t *testing.T) {
+	_, err := NewLocalBackend("/tmp/model.gguf")
+	if !errors.Is(err, ErrLocalBackendUnavailable) {
+		t.Errorf("Expected ErrLocalBackendUnavailable, got %v", err)
+	}
+}
+
+// stubBackend is a minimal Backend used to verify WithBackend actually
+// routes client calls through the configured backend.
+type stubBackend struct {
+	captions       int
+	captionStreams int
+}
+
+func (b *stubBackend) Caption(ctx context.Context, source ImageSource, length string, stream bool) (string, error) {
+	b.captions++
+	return "stub caption", nil
+}
+func (b *stubBackend) Query(ctx context.Context, source ImageSource, question string) (string, error) {
+	return "stub answer", nil
+}
+func (b *stubBackend) Detect(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error) {
+	return nil, nil
+}
+func (b *stubBackend) Point(ctx context.Context, source ImageSource, object string) ([]Point, error) {
+	return nil, nil
+}
+func (b *stubBackend) CaptionStream(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error) {
+	b.captionStreams++
+	chunks := make(chan CaptionChunk, 1)
+	chunks <- CaptionChunk{Chunk: "stub stream", Completed: true}
+	close(chunks)
+	return chunks, nil
+}
+func (b *stubBackend) QueryStream(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error) {
+	return nil, nil
+}
+
+func TestWithBackendOverridesDefaultHTTPBackend(t *testing.T) {
+	backend := &stubBackend{}
+	client := NewMoondreamClient("test-api-key", WithBackend(backend))
+
+	caption, err := client.CaptionImage(context.Background(), ImageFromBytes([]byte("test")), "short", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caption != "stub caption" {
+		t.Errorf("Expected stub caption, got %q", caption)
+	}
+	if backend.captions != 1 {
+		t.Errorf("Expected the stub backend to be called once, got %d", backend.captions)
+	}
+}
+
+func TestWithBackendOverridesStreaming(t *testing.T) {
+	backend := &stubBackend{}
+	client := NewMoondreamClient("test-api-key", WithBackend(backend))
+
+	chunks, err := client.CaptionStreamImage(context.Background(), ImageFromBytes([]byte("test")), "short")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	for chunk := range chunks {
+		got += chunk.Chunk
+	}
+
+	if got != "stub stream" {
+		t.Errorf("Expected stub stream output, got %q", got)
+	}
+	if backend.captionStreams != 1 {
+		t.Errorf("Expected the stub backend's CaptionStream to be called once, got %d", backend.captionStreams)
+	}
+}
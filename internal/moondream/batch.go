@@ -0,0 +1,163 @@
+package moondream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions configures how a batch operation distributes work across
+// concurrent requests.
+type BatchOptions struct {
+	// Concurrency is the maximum number of in-flight requests. Values <= 0
+	// are treated as 1 (sequential processing).
+	Concurrency int
+	// StopOnError cancels any requests that haven't started yet as soon as
+	// one item fails. Requests already in flight are allowed to finish.
+	StopOnError bool
+	// OnProgress, if set, is called after each item completes with the
+	// number of items completed so far and the total item count. It may be
+	// called concurrently from multiple goroutines.
+	OnProgress func(completed, total int)
+}
+
+// BatchResult is the outcome of processing a single item in a batch
+// operation. Index is the item's position in the input slice; results are
+// always received in that same order, even though the underlying work runs
+// concurrently and may finish out of order.
+type BatchResult[T any] struct {
+	Index int
+	Value T
+	Err   error
+}
+
+// BatchCaption generates captions for sources, running up to
+// opts.Concurrency requests at a time.
+func (c *MoondreamClient) BatchCaption(ctx context.Context, sources []ImageSource, length string, opts BatchOptions) (<-chan BatchResult[string], error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no image sources provided")
+	}
+	return runBatch(ctx, len(sources), opts, func(ctx context.Context, i int) (string, error) {
+		return c.CaptionImage(ctx, sources[i], length, false)
+	}), nil
+}
+
+// BatchQuery asks question about each of sources, running up to
+// opts.Concurrency requests at a time.
+func (c *MoondreamClient) BatchQuery(ctx context.Context, sources []ImageSource, question string, opts BatchOptions) (<-chan BatchResult[string], error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no image sources provided")
+	}
+	return runBatch(ctx, len(sources), opts, func(ctx context.Context, i int) (string, error) {
+		return c.QueryImage(ctx, sources[i], question)
+	}), nil
+}
+
+// BatchDetect detects object in each of sources, running up to
+// opts.Concurrency requests at a time.
+func (c *MoondreamClient) BatchDetect(ctx context.Context, sources []ImageSource, object string, opts BatchOptions) (<-chan BatchResult[[]BoundingBox], error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no image sources provided")
+	}
+	return runBatch(ctx, len(sources), opts, func(ctx context.Context, i int) ([]BoundingBox, error) {
+		return c.DetectImage(ctx, sources[i], object)
+	}), nil
+}
+
+// BatchPoint points at object in each of sources, running up to
+// opts.Concurrency requests at a time.
+func (c *MoondreamClient) BatchPoint(ctx context.Context, sources []ImageSource, object string, opts BatchOptions) (<-chan BatchResult[[]Point], error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no image sources provided")
+	}
+	return runBatch(ctx, len(sources), opts, func(ctx context.Context, i int) ([]Point, error) {
+		return c.PointImage(ctx, sources[i], object)
+	}), nil
+}
+
+// runBatch fans work out across up to opts.Concurrency goroutines, one per
+// item in [0, n), and streams results back on the returned channel in input
+// order - item i is never sent before item i-1, regardless of which
+// goroutine finishes first. The channel is closed once every launched item
+// has been emitted, the context is canceled, or (when opts.StopOnError is
+// set) an item fails; items that never got a chance to start are simply
+// never emitted.
+func runBatch[T any](ctx context.Context, n int, opts BatchOptions, work func(ctx context.Context, i int) (T, error)) <-chan BatchResult[T] {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	// slots[i] carries the result for item i once it completes, letting the
+	// emitter goroutine below block on them in order while the workers
+	// themselves still run, and finish, out of order.
+	slots := make([]chan BatchResult[T], n)
+	for i := range slots {
+		slots[i] = make(chan BatchResult[T], 1)
+	}
+
+	go func() {
+		cctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		var completed int32
+		attempted := 0
+
+	items:
+		for i := 0; i < n; i++ {
+			select {
+			case <-cctx.Done():
+				break items
+			case sem <- struct{}{}:
+			}
+			// Both cases can be ready at once; don't launch after cancellation.
+			if cctx.Err() != nil {
+				<-sem
+				break items
+			}
+			attempted++
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				value, err := work(ctx, i)
+				slots[i] <- BatchResult[T]{Index: i, Value: value, Err: err}
+
+				if err != nil && opts.StopOnError {
+					cancel()
+				}
+
+				if opts.OnProgress != nil {
+					opts.OnProgress(int(atomic.AddInt32(&completed, 1)), n)
+				}
+			}(i)
+		}
+
+		wg.Wait()
+
+		// Items from attempted onward never got launched; close their slots
+		// so the emitter doesn't block waiting on work that never happened.
+		for i := attempted; i < n; i++ {
+			close(slots[i])
+		}
+	}()
+
+	out := make(chan BatchResult[T], n)
+	go func() {
+		defer close(out)
+		for i := 0; i < n; i++ {
+			result, ok := <-slots[i]
+			if !ok {
+				return
+			}
+			out <- result
+		}
+	}()
+
+	return out
+}
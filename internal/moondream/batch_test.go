@@ -0,0 +1,194 @@
+package moondream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCaptionServer returns a test server that calls onRequest for every
+// /caption request before responding with a fixed caption.
+func newCaptionServer(t *testing.T, onRequest func()) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		onRequest()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"caption": "test caption"}`))
+	}))
+}
+
+// newCaptionServerWithStatus returns a test server whose response status
+// for each /caption request is determined by statusFor.
+func newCaptionServerWithStatus(t *testing.T, statusFor func() int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statusFor()
+		if status >= 400 {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"status_code": ` + "500" + `, "message": "boom"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"caption": "test caption"}`))
+	}))
+}
+
+func TestBatchCaptionConcurrencyLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := newCaptionServer(t, func() {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&maxInFlight)
+			if n <= old || atomic.CompareAndSwapInt32(&maxInFlight, old, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	})
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	sources := make([]ImageSource, 8)
+	for i := range sources {
+		sources[i] = ImageFromBytes([]byte("test image data"))
+	}
+
+	results, err := client.BatchCaption(context.Background(), sources, "short", BatchOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for r := range results {
+		if r.Err != nil {
+			t.Errorf("unexpected error for item %d: %v", r.Index, r.Err)
+		}
+		count++
+	}
+
+	if count != len(sources) {
+		t.Errorf("Expected %d results, got %d", len(sources), count)
+	}
+	if atomic.LoadInt32(&maxInFlight) > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, observed %d", maxInFlight)
+	}
+}
+
+func TestBatchCaptionPreservesOrder(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Make earlier-indexed requests finish later than later-indexed
+		// ones, so in-order delivery can't be mistaken for completion order.
+		n := atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Duration(20-n) * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"caption": "test caption"}`))
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	sources := make([]ImageSource, 10)
+	for i := range sources {
+		sources[i] = ImageFromBytes([]byte("test image data"))
+	}
+
+	results, err := client.BatchCaption(context.Background(), sources, "short", BatchOptions{Concurrency: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := 0
+	for r := range results {
+		if r.Index != next {
+			t.Fatalf("Expected results in order, got index %d when expecting %d", r.Index, next)
+		}
+		next++
+	}
+	if next != len(sources) {
+		t.Errorf("Expected %d results, got %d", len(sources), next)
+	}
+}
+
+func TestBatchCaptionContextCancel(t *testing.T) {
+	server := newCaptionServer(t, func() {
+		time.Sleep(100 * time.Millisecond)
+	})
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	sources := make([]ImageSource, 10)
+	for i := range sources {
+		sources[i] = ImageFromBytes([]byte("test image data"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	results, err := client.BatchCaption(ctx, sources, "short", BatchOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for range results {
+		count++
+	}
+
+	if count >= len(sources) {
+		t.Errorf("Expected cancellation to cut the batch short, got all %d results", count)
+	}
+}
+
+func TestBatchCaptionStopOnError(t *testing.T) {
+	var calls int32
+	server := newCaptionServerWithStatus(t, func() int {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return 500
+		}
+		return 200
+	})
+	defer server.Close()
+
+	client := NewMoondreamClient(
+		"test-api-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(&ExponentialBackoffPolicy{MaxRetries: 0}),
+	)
+
+	sources := make([]ImageSource, 20)
+	for i := range sources {
+		sources[i] = ImageFromBytes([]byte("test image data"))
+	}
+
+	results, err := client.BatchCaption(context.Background(), sources, "short", BatchOptions{
+		Concurrency: 1,
+		StopOnError: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sawError := false
+	count := 0
+	for r := range results {
+		count++
+		if r.Err != nil {
+			sawError = true
+		}
+	}
+
+	if !sawError {
+		t.Error("Expected at least one error result")
+	}
+	if count >= len(sources) {
+		t.Errorf("Expected StopOnError to cut the batch short, got all %d results", count)
+	}
+}
@@ -3,12 +3,10 @@ package moondream
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"time"
 )
 
@@ -24,6 +22,7 @@ type MoondreamClient struct {
 	config  ClientConfig
 	client  *http.Client
 	apiKey  string
+	backend Backend
 }
 
 // NewMoondreamClient creates a new client with the given API key and options
@@ -34,12 +33,18 @@ func NewMoondreamClient(apiKey string, opts ...ClientOption) *MoondreamClient {
 		MaxRetries: defaultMaxRetries,
 		RetryDelay: defaultRetryDelay,
 	}
+	config.RetryPolicy = &ExponentialBackoffPolicy{
+		MaxRetries: config.MaxRetries,
+		BaseDelay:  config.RetryDelay,
+		MaxDelay:   defaultMaxRetryDelay,
+	}
 
 	client := &MoondreamClient{
 		config: config,
 		client: &http.Client{Timeout: config.Timeout},
 		apiKey: apiKey,
 	}
+	client.backend = &HTTPBackend{client: client}
 
 	// Apply options
 	for _, opt := range opts {
@@ -64,14 +69,33 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithBackend overrides how inference is performed, replacing the default
+// HTTPBackend that calls the hosted API. The bundled LocalBackend returns
+// an error from every method.
+func WithBackend(backend Backend) ClientOption {
+	return func(c *MoondreamClient) {
+		c.backend = backend
+	}
+}
+
+// WithRetryPolicy overrides the policy used to decide whether and how long
+// to wait before retrying a failed request. The default is
+// DefaultRetryPolicy, an ExponentialBackoffPolicy seeded from the client's
+// MaxRetries/RetryDelay.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *MoondreamClient) {
+		c.config.RetryPolicy = policy
+	}
+}
+
+// encodeImage reads imagePath from disk and returns its data URI, sniffing
+// the MIME type from the file's contents.
 func (c *MoondreamClient) encodeImage(imagePath string) (string, error) {
-	imageData, err := os.ReadFile(imagePath)
+	source, err := ImageFromFile(imagePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read image file: %w", err)
+		return "", err
 	}
-
-	// Add data URI prefix for base64 encoded image
-	return fmt.Sprintf("data:image/jpeg;base64,%s", base64.StdEncoding.EncodeToString(imageData)), nil
+	return source.encoded(), nil
 }
 
 func (c *MoondreamClient) sendRequest(ctx context.Context, endpoint string, payload interface{}, result interface{}) error {
@@ -80,34 +104,44 @@ func (c *MoondreamClient) sendRequest(ctx context.Context, endpoint string, payl
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.config.BaseURL+endpoint, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	policy := c.config.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
 	}
 
-	req.Header.Set("X-Moondream-Auth", c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
 	var lastErr error
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(c.config.RetryDelay * time.Duration(attempt)):
-			}
+	for attempt := 0; ; attempt++ {
+		// Rebuild the request each attempt - the previous attempt's body
+		// reader is already drained by the time we'd retry.
+		req, err := http.NewRequestWithContext(ctx, "POST", c.config.BaseURL+endpoint, bytes.NewReader(jsonPayload))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
 		}
+		req.Header.Set("X-Moondream-Auth", c.apiKey)
+		req.Header.Set("Content-Type", "application/json")
 
 		resp, err := c.client.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to send request: %w", err)
+			if !policy.ShouldRetry(attempt, nil, err) {
+				return fmt.Errorf("max retries exceeded: %w", lastErr)
+			}
+			if err := c.sleep(ctx, policy.Delay(attempt, nil)); err != nil {
+				return err
+			}
 			continue
 		}
-		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close() // close explicitly here so attempts don't pile up open bodies
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			if !policy.ShouldRetry(attempt, resp, err) {
+				return fmt.Errorf("max retries exceeded: %w", lastErr)
+			}
+			if err := c.sleep(ctx, policy.Delay(attempt, resp)); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -120,96 +154,94 @@ func (c *MoondreamClient) sendRequest(ctx context.Context, endpoint string, payl
 				}
 			}
 			lastErr = &apiErr
-			if resp.StatusCode < 500 { // Don't retry client errors
+			if !policy.ShouldRetry(attempt, resp, nil) {
 				return lastErr
 			}
+			if err := c.sleep(ctx, policy.Delay(attempt, resp)); err != nil {
+				return err
+			}
 			continue
 		}
 
 		if err := json.Unmarshal(body, result); err != nil {
-			lastErr = fmt.Errorf("failed to unmarshal response: %w", err)
-			continue
+			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 
 		return nil
 	}
-
-	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-func (c *MoondreamClient) Caption(ctx context.Context, imagePath string, length string, stream bool) (string, error) {
-	encodedImage, err := c.encodeImage(imagePath)
-	if err != nil {
-		return "", err
+// sleep waits for d, returning ctx.Err() if ctx is canceled first. A
+// non-positive d returns immediately.
+func (c *MoondreamClient) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
 	}
-
-	req := CaptionRequest{
-		Image:  encodedImage,
-		Length: length,
-		Stream: stream,
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
 	}
+}
 
-	var resp CaptionResponse
-	if err := c.sendRequest(ctx, "/caption", req, &resp); err != nil {
+func (c *MoondreamClient) Caption(ctx context.Context, imagePath string, length string, stream bool) (string, error) {
+	source, err := ImageFromFile(imagePath)
+	if err != nil {
 		return "", err
 	}
+	return c.CaptionImage(ctx, source, length, stream)
+}
 
-	return resp.Caption, nil
+// CaptionImage generates a caption for an ImageSource, letting callers
+// supply images from memory, an io.Reader, or a URL instead of a file path.
+// It runs against whichever Backend the client was configured with.
+func (c *MoondreamClient) CaptionImage(ctx context.Context, source ImageSource, length string, stream bool) (string, error) {
+	return c.backend.Caption(ctx, source, length, stream)
 }
 
 func (c *MoondreamClient) Query(ctx context.Context, imagePath string, question string) (string, error) {
-	encodedImage, err := c.encodeImage(imagePath)
+	source, err := ImageFromFile(imagePath)
 	if err != nil {
 		return "", err
 	}
+	return c.QueryImage(ctx, source, question)
+}
 
-	req := QueryRequest{
-		Image:    encodedImage,
-		Question: question,
-	}
-
-	var resp QueryResponse
-	if err := c.sendRequest(ctx, "/query", req, &resp); err != nil {
-		return "", err
-	}
-
-	return resp.Answer, nil
+// QueryImage asks a question about an ImageSource, letting callers supply
+// images from memory, an io.Reader, or a URL instead of a file path. It
+// runs against whichever Backend the client was configured with.
+func (c *MoondreamClient) QueryImage(ctx context.Context, source ImageSource, question string) (string, error) {
+	return c.backend.Query(ctx, source, question)
 }
 
-func (c *MoondreamClient) Detect(ctx context.Context, imagePath string, object string) ([]map[string]float64, error) {
-	encodedImage, err := c.encodeImage(imagePath)
+func (c *MoondreamClient) Detect(ctx context.Context, imagePath string, object string) ([]BoundingBox, error) {
+	source, err := ImageFromFile(imagePath)
 	if err != nil {
 		return nil, err
 	}
+	return c.DetectImage(ctx, source, object)
+}
 
-	req := DetectRequest{
-		Image:  encodedImage,
-		Object: object,
-	}
-
-	var resp DetectResponse
-	if err := c.sendRequest(ctx, "/detect", req, &resp); err != nil {
-		return nil, err
-	}
-
-	return resp.BoundingBoxes, nil
+// DetectImage detects instances of object in an ImageSource, letting callers
+// supply images from memory, an io.Reader, or a URL instead of a file path.
+// It runs against whichever Backend the client was configured with.
+func (c *MoondreamClient) DetectImage(ctx context.Context, source ImageSource, object string) ([]BoundingBox, error) {
+	return c.backend.Detect(ctx, source, object)
 }
 
-func (c *MoondreamClient) Point(ctx context.Context, imagePath string, object string) ([]map[string]float64, error) {
-	encodedImage, err := c.encodeImage(imagePath)
+func (c *MoondreamClient) Point(ctx context.Context, imagePath string, object string) ([]Point, error) {
+	source, err := ImageFromFile(imagePath)
 	if err != nil {
 		return nil, err
 	}
+	return c.PointImage(ctx, source, object)
+}
 
-	req := PointRequest{
-		Image:  encodedImage,
-		Object: object,
-	}
-
-	var resp PointResponse
-	if err := c.sendRequest(ctx, "/point", req, &resp); err != nil {
-		return nil, err
-	}
-
-	return resp.Points, nil
+// PointImage points at instances of object in an ImageSource, letting
+// callers supply images from memory, an io.Reader, or a URL instead of a
+// file path. It runs against whichever Backend the client was configured
+// with.
+func (c *MoondreamClient) PointImage(ctx context.Context, source ImageSource, object string) ([]Point, error) {
+	return c.backend.Point(ctx, source, object)
 }
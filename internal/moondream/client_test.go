@@ -54,8 +54,8 @@ func TestEncodeImage(t *testing.T) {
 	}
 	defer os.Remove(tmpfile.Name())
 
-	// Write some dummy image data
-	testData := []byte("test image data")
+	// Write a minimal PNG signature so MIME sniffing recognizes it.
+	testData := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
 	if _, err := tmpfile.Write(testData); err != nil {
 		t.Fatal(err)
 	}
@@ -70,7 +70,7 @@ func TestEncodeImage(t *testing.T) {
 	}
 
 	// Remove data URI prefix
-	prefix := "data:image/jpeg;base64,"
+	prefix := "data:image/png;base64,"
 	if !strings.HasPrefix(encoded, prefix) {
 		t.Fatalf("Expected data URI prefix %q, got %q", prefix, encoded[:min(len(encoded), len(prefix))])
 	}
@@ -87,6 +87,27 @@ func TestEncodeImage(t *testing.T) {
 	}
 }
 
+func TestCaptionRejectsStreamTrue(t *testing.T) {
+	client := NewMoondreamClient("test-api-key")
+
+	tmpfile, err := os.CreateTemp("", "test-*.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte("test image data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Caption(context.Background(), tmpfile.Name(), "long", true)
+	if err == nil {
+		t.Fatal("Expected Caption(stream=true) to return an error instead of silently ignoring it")
+	}
+}
+
 func TestCaption(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -286,17 +307,9 @@ func TestDetect(t *testing.T) {
 	}
 
 	box := boxes[0]
-	expectedValues := map[string]float64{
-		"x":      0.5,
-		"y":      0.5,
-		"width":  0.3,
-		"height": 0.4,
-	}
-
-	for key, expected := range expectedValues {
-		if got := box[key]; got != expected {
-			t.Errorf("Expected %s = %f, got %f", key, expected, got)
-		}
+	want := BoundingBox{XMin: 0.35, YMin: 0.3, XMax: 0.65, YMax: 0.7}
+	if box != want {
+		t.Errorf("Expected bounding box %+v, got %+v", want, box)
 	}
 }
 
@@ -351,15 +364,9 @@ func TestPoint(t *testing.T) {
 	}
 
 	point := points[0]
-	expectedValues := map[string]float64{
-		"x": 0.3,
-		"y": 0.7,
-	}
-
-	for key, expected := range expectedValues {
-		if got := point[key]; got != expected {
-			t.Errorf("Expected %s = %f, got %f", key, expected, got)
-		}
+	want := Point{X: 0.3, Y: 0.7}
+	if point != want {
+		t.Errorf("Expected point %+v, got %+v", want, point)
 	}
 }
 
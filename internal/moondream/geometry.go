@@ -0,0 +1,117 @@
+package moondream
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"math"
+)
+
+// BoundingBox is a detected object's location, normalized to [0, 1] relative
+// to the image's width and height. It unmarshals from either corner
+// (x_min/y_min/x_max/y_max) or center+size (x/y/width/height) JSON
+// encodings, always normalizing to the corner form.
+type BoundingBox struct {
+	XMin, YMin, XMax, YMax float64
+	Confidence             float64
+	Label                  string
+}
+
+// UnmarshalJSON accepts both the corner and center+size encodings the API
+// may return for a detected object.
+func (b *BoundingBox) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		XMin       *float64 `json:"x_min"`
+		YMin       *float64 `json:"y_min"`
+		XMax       *float64 `json:"x_max"`
+		YMax       *float64 `json:"y_max"`
+		X          *float64 `json:"x"`
+		Y          *float64 `json:"y"`
+		Width      *float64 `json:"width"`
+		Height     *float64 `json:"height"`
+		Confidence float64  `json:"confidence"`
+		Label      string   `json:"label"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	switch {
+	case aux.XMin != nil && aux.YMin != nil && aux.XMax != nil && aux.YMax != nil:
+		b.XMin, b.YMin, b.XMax, b.YMax = *aux.XMin, *aux.YMin, *aux.XMax, *aux.YMax
+	case aux.X != nil && aux.Y != nil && aux.Width != nil && aux.Height != nil:
+		halfW, halfH := *aux.Width/2, *aux.Height/2
+		b.XMin = *aux.X - halfW
+		b.YMin = *aux.Y - halfH
+		b.XMax = *aux.X + halfW
+		b.YMax = *aux.Y + halfH
+	default:
+		return fmt.Errorf("bounding box has neither corner (x_min/y_min/x_max/y_max) nor center+size (x/y/width/height) fields: %s", data)
+	}
+
+	b.Confidence = aux.Confidence
+	b.Label = aux.Label
+	return nil
+}
+
+// ToPixels converts a normalized bounding box to pixel coordinates for an
+// image of the given dimensions.
+func (b BoundingBox) ToPixels(imgWidth, imgHeight int) image.Rectangle {
+	return image.Rect(
+		int(b.XMin*float64(imgWidth)),
+		int(b.YMin*float64(imgHeight)),
+		int(b.XMax*float64(imgWidth)),
+		int(b.YMax*float64(imgHeight)),
+	)
+}
+
+// IoU returns the intersection-over-union of b and other, both normalized
+// bounding boxes. Useful for non-maximum suppression over detection
+// results.
+func (b BoundingBox) IoU(other BoundingBox) float64 {
+	interXMin := math.Max(b.XMin, other.XMin)
+	interYMin := math.Max(b.YMin, other.YMin)
+	interXMax := math.Min(b.XMax, other.XMax)
+	interYMax := math.Min(b.YMax, other.YMax)
+
+	interArea := math.Max(0, interXMax-interXMin) * math.Max(0, interYMax-interYMin)
+	if interArea <= 0 {
+		return 0
+	}
+
+	areaB := math.Max(0, b.XMax-b.XMin) * math.Max(0, b.YMax-b.YMin)
+	areaOther := math.Max(0, other.XMax-other.XMin) * math.Max(0, other.YMax-other.YMin)
+	unionArea := areaB + areaOther - interArea
+	if unionArea <= 0 {
+		return 0
+	}
+
+	return interArea / unionArea
+}
+
+// Point is a location the API pointed at in an image, normalized to [0, 1]
+// relative to the image's width and height.
+type Point struct {
+	X, Y  float64
+	Label string
+}
+
+// UnmarshalJSON decodes a point's x/y/label fields.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		X     float64 `json:"x"`
+		Y     float64 `json:"y"`
+		Label string  `json:"label"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.X, p.Y, p.Label = aux.X, aux.Y, aux.Label
+	return nil
+}
+
+// ToPixels converts a normalized point to pixel coordinates for an image of
+// the given dimensions.
+func (p Point) ToPixels(imgWidth, imgHeight int) image.Point {
+	return image.Pt(int(p.X*float64(imgWidth)), int(p.Y*float64(imgHeight)))
+}
@@ -0,0 +1,70 @@
+package moondream
+
+import (
+	"encoding/json"
+	"image"
+	"testing"
+)
+
+func TestBoundingBoxUnmarshalJSONCornerForm(t *testing.T) {
+	var box BoundingBox
+	if err := json.Unmarshal([]byte(`{"x_min": 0.1, "y_min": 0.2, "x_max": 0.3, "y_max": 0.4, "label": "cat"}`), &box); err != nil {
+		t.Fatal(err)
+	}
+	want := BoundingBox{XMin: 0.1, YMin: 0.2, XMax: 0.3, YMax: 0.4, Label: "cat"}
+	if box != want {
+		t.Errorf("Expected %+v, got %+v", want, box)
+	}
+}
+
+func TestBoundingBoxUnmarshalJSONCenterSizeForm(t *testing.T) {
+	var box BoundingBox
+	if err := json.Unmarshal([]byte(`{"x": 0.5, "y": 0.5, "width": 0.2, "height": 0.4}`), &box); err != nil {
+		t.Fatal(err)
+	}
+	want := BoundingBox{XMin: 0.4, YMin: 0.3, XMax: 0.6, YMax: 0.7}
+	if box != want {
+		t.Errorf("Expected %+v, got %+v", want, box)
+	}
+}
+
+func TestBoundingBoxUnmarshalJSONMissingFields(t *testing.T) {
+	var box BoundingBox
+	if err := json.Unmarshal([]byte(`{"label": "cat"}`), &box); err == nil {
+		t.Error("Expected an error for a bounding box with neither encoding's fields")
+	}
+}
+
+func TestBoundingBoxToPixels(t *testing.T) {
+	box := BoundingBox{XMin: 0.25, YMin: 0.5, XMax: 0.75, YMax: 1}
+	got := box.ToPixels(400, 200)
+	want := image.Rect(100, 100, 300, 200)
+	if got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestBoundingBoxIoU(t *testing.T) {
+	a := BoundingBox{XMin: 0, YMin: 0, XMax: 1, YMax: 1}
+	b := BoundingBox{XMin: 0.5, YMin: 0, XMax: 1.5, YMax: 1}
+
+	if got := a.IoU(b); got != 1.0/3.0 {
+		t.Errorf("Expected IoU 1/3, got %v", got)
+	}
+
+	disjoint := BoundingBox{XMin: 2, YMin: 2, XMax: 3, YMax: 3}
+	if got := a.IoU(disjoint); got != 0 {
+		t.Errorf("Expected IoU 0 for disjoint boxes, got %v", got)
+	}
+}
+
+func TestPointUnmarshalJSON(t *testing.T) {
+	var p Point
+	if err := json.Unmarshal([]byte(`{"x": 0.3, "y": 0.7, "label": "cat"}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	want := Point{X: 0.3, Y: 0.7, Label: "cat"}
+	if p != want {
+		t.Errorf("Expected %+v, got %+v", want, p)
+	}
+}
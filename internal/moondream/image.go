@@ -0,0 +1,80 @@
+package moondream
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ImageSource represents image data to send to the API, either as an
+// inline data URI or as an HTTPS URL the API can fetch directly. Construct
+// one with ImageFromFile, ImageFromBytes, ImageFromReader, or ImageFromURL.
+type ImageSource struct {
+	dataURI string
+	url     string
+}
+
+// ImageFromFile reads an image from disk and sniffs its MIME type.
+func ImageFromFile(path string) (ImageSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ImageSource{}, fmt.Errorf("failed to read image file: %w", err)
+	}
+	return ImageFromBytes(data), nil
+}
+
+// ImageFromBytes wraps raw image bytes, sniffing the MIME type via
+// http.DetectContentType.
+func ImageFromBytes(data []byte) ImageSource {
+	mimeType := http.DetectContentType(data)
+	return ImageSource{
+		dataURI: fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)),
+	}
+}
+
+// ImageFromReader reads all of r into memory and wraps it as an ImageSource.
+// Use ImageFromBytes instead if the caller already holds the data in memory.
+func ImageFromReader(r io.Reader) (ImageSource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ImageSource{}, fmt.Errorf("failed to read image: %w", err)
+	}
+	return ImageFromBytes(data), nil
+}
+
+// ImageFromURL passes an HTTPS URL through to the API unmodified, letting
+// the API fetch the image itself instead of the caller encoding it.
+func ImageFromURL(rawURL string) (ImageSource, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ImageSource{}, fmt.Errorf("invalid image URL: %w", err)
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return ImageSource{}, fmt.Errorf("image URL must be http(s), got scheme %q", parsed.Scheme)
+	}
+	return ImageSource{url: rawURL}, nil
+}
+
+// encoded returns the value to send as the request's image_url field.
+func (s ImageSource) encoded() string {
+	if s.url != "" {
+		return s.url
+	}
+	return s.dataURI
+}
+
+// String implements fmt.Stringer, truncating inline data URIs so logging an
+// ImageSource doesn't dump the full base64 payload.
+func (s ImageSource) String() string {
+	if s.url != "" {
+		return s.url
+	}
+	if i := strings.Index(s.dataURI, ","); i >= 0 && i+16 < len(s.dataURI) {
+		return s.dataURI[:i+16] + "..."
+	}
+	return s.dataURI
+}
@@ -0,0 +1,46 @@
+package moondream
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestImageFromBytesDetectsMIMEType(t *testing.T) {
+	// Minimal PNG signature, enough for http.DetectContentType to recognize it.
+	png := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	source := ImageFromBytes(png)
+
+	if !strings.HasPrefix(source.encoded(), "data:image/png;base64,") {
+		t.Errorf("Expected image/png data URI, got %q", source.encoded())
+	}
+}
+
+func TestImageFromReader(t *testing.T) {
+	data := []byte("test image data")
+	source, err := ImageFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(source.encoded(), "data:") {
+		t.Errorf("Expected a data URI, got %q", source.encoded())
+	}
+}
+
+func TestImageFromURL(t *testing.T) {
+	source, err := ImageFromURL("https://example.com/cat.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := source.encoded(); got != "https://example.com/cat.jpg" {
+		t.Errorf("Expected URL to pass through unmodified, got %q", got)
+	}
+}
+
+func TestImageFromURLRejectsInvalidScheme(t *testing.T) {
+	if _, err := ImageFromURL("ftp://example.com/cat.jpg"); err == nil {
+		t.Error("Expected an error for a non-http(s) scheme")
+	}
+}
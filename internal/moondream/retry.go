@@ -0,0 +1,129 @@
+package moondream
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaxRetryDelay caps the exponential backoff computed by
+// ExponentialBackoffPolicy before jitter is applied.
+const defaultMaxRetryDelay = 30 * time.Second
+
+// RetryPolicy decides whether a failed request attempt should be retried
+// and how long to wait before the next one. Implement this to customize
+// retry behavior beyond ExponentialBackoffPolicy, and install it with
+// WithRetryPolicy.
+type RetryPolicy interface {
+	// ShouldRetry reports whether attempt (0-indexed, the attempt that just
+	// failed) should be retried. resp is nil on a network error; err is nil
+	// on an HTTP-level failure (4xx/5xx).
+	ShouldRetry(attempt int, resp *http.Response, err error) bool
+
+	// Delay returns how long to wait before the next attempt. resp is the
+	// response that triggered the retry, or nil on a network error.
+	Delay(attempt int, resp *http.Response) time.Duration
+}
+
+// ExponentialBackoffPolicy is the default RetryPolicy. It retries 429 and
+// 5xx responses plus timeout/connection-reset network errors, using
+// exponential backoff with full jitter, and honors a Retry-After header on
+// 429 responses when present.
+type ExponentialBackoffPolicy struct {
+	// MaxRetries is the number of retry attempts after the initial try.
+	MaxRetries int
+	// BaseDelay is the backoff for the first retry; it doubles each
+	// subsequent attempt up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff before jitter is applied.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the ExponentialBackoffPolicy used when no
+// RetryPolicy is configured via WithRetryPolicy.
+func DefaultRetryPolicy() *ExponentialBackoffPolicy {
+	return &ExponentialBackoffPolicy{
+		MaxRetries: defaultMaxRetries,
+		BaseDelay:  defaultRetryDelay,
+		MaxDelay:   defaultMaxRetryDelay,
+	}
+}
+
+func (p *ExponentialBackoffPolicy) ShouldRetry(attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+
+	if err != nil {
+		return isRetryableNetworkError(err)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func (p *ExponentialBackoffPolicy) Delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+
+	backoff := p.BaseDelay << uint(attempt)
+	if backoff <= 0 || backoff > maxDelay {
+		backoff = maxDelay
+	}
+
+	// Full jitter: sleep for a random duration in [0, backoff].
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+// isRetryableNetworkError reports whether err looks transient - a timeout
+// or a connection reset - as opposed to a permanent failure like an
+// unresolvable host or a malformed URL.
+func isRetryableNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return strings.Contains(err.Error(), "connection reset") ||
+		strings.Contains(err.Error(), "broken pipe") ||
+		errors.Is(err, net.ErrClosed)
+}
+
+// parseRetryAfter parses a Retry-After header value, which per RFC 9110 is
+// either a number of seconds or an HTTP-date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(header); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
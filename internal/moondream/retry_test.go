@@ -0,0 +1,107 @@
+package moondream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoffPolicyDelayRespectsMaxDelay(t *testing.T) {
+	policy := &ExponentialBackoffPolicy{MaxRetries: 10, BaseDelay: time.Second, MaxDelay: 2 * time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		d := policy.Delay(attempt, nil)
+		if d < 0 || d > 2*time.Second {
+			t.Errorf("attempt %d: delay %v out of [0, 2s] bounds", attempt, d)
+		}
+	}
+}
+
+func TestExponentialBackoffPolicyShouldRetry(t *testing.T) {
+	policy := &ExponentialBackoffPolicy{MaxRetries: 2}
+
+	tooManyRequests := &http.Response{StatusCode: http.StatusTooManyRequests}
+	serverError := &http.Response{StatusCode: http.StatusInternalServerError}
+	badRequest := &http.Response{StatusCode: http.StatusBadRequest}
+
+	if !policy.ShouldRetry(0, tooManyRequests, nil) {
+		t.Error("expected 429 to be retryable")
+	}
+	if !policy.ShouldRetry(0, serverError, nil) {
+		t.Error("expected 5xx to be retryable")
+	}
+	if policy.ShouldRetry(0, badRequest, nil) {
+		t.Error("expected 4xx (other than 429) not to be retryable")
+	}
+	if policy.ShouldRetry(2, serverError, nil) {
+		t.Error("expected ShouldRetry to respect MaxRetries")
+	}
+}
+
+func TestExponentialBackoffPolicyHonorsRetryAfterSeconds(t *testing.T) {
+	policy := &ExponentialBackoffPolicy{MaxRetries: 1}
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"2"}},
+	}
+
+	if d := policy.Delay(0, resp); d != 2*time.Second {
+		t.Errorf("expected Retry-After to yield a 2s delay, got %v", d)
+	}
+}
+
+func TestSendRequestRetriesOn500ThenSucceeds(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"status_code": 500, "message": "transient"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"caption": "test caption"}`))
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient(
+		"test-api-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(&ExponentialBackoffPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	)
+
+	var resp CaptionResponse
+	err := client.sendRequest(context.Background(), "/caption", CaptionRequest{Image: "x"}, &resp)
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if resp.Caption != "test caption" {
+		t.Errorf("unexpected caption %q", resp.Caption)
+	}
+	if calls != 2 {
+		t.Errorf("expected exactly 2 calls, got %d", calls)
+	}
+}
+
+func TestSendRequestDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status_code": 400, "message": "bad request"}`))
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	var resp CaptionResponse
+	err := client.sendRequest(context.Background(), "/caption", CaptionRequest{Image: "x"}, &resp)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected exactly 1 call for a non-retryable error, got %d", calls)
+	}
+}
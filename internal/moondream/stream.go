@@ -0,0 +1,239 @@
+package moondream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CaptionChunk represents a single token (or the final frame) of a streamed
+// caption response.
+type CaptionChunk struct {
+	Chunk     string `json:"chunk"`
+	Completed bool   `json:"completed"`
+}
+
+// QueryChunk represents a single token (or the final frame) of a streamed
+// query response.
+type QueryChunk struct {
+	Chunk     string `json:"chunk"`
+	Completed bool   `json:"completed"`
+}
+
+// doneSentinel is the terminating frame the API sends to mark the end of a
+// stream, independent of any "completed" field in the payload itself.
+const doneSentinel = "[DONE]"
+
+// sendStreamingRequest issues a POST request with Accept: text/event-stream
+// and hands the caller the live response body instead of buffering it. The
+// caller is responsible for closing the returned body once it is done
+// reading frames from it.
+func (b *HTTPBackend) sendStreamingRequest(ctx context.Context, endpoint string, payload interface{}) (io.ReadCloser, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", b.client.config.BaseURL+endpoint, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-Moondream-Auth", b.client.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := b.client.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		var apiErr APIError
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			apiErr = APIError{
+				StatusCode: resp.StatusCode,
+				Message:    string(body),
+			}
+		}
+		return nil, &apiErr
+	}
+
+	return resp.Body, nil
+}
+
+// CaptionStream streams a caption for source from the hosted API, returning
+// a channel of tokens as they arrive. The channel is closed once the stream
+// completes, the context is canceled, or an unrecoverable read error occurs.
+func (b *HTTPBackend) CaptionStream(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error) {
+	req := CaptionRequest{
+		Image:  source.encoded(),
+		Length: length,
+		Stream: true,
+	}
+
+	body, err := b.sendStreamingRequest(ctx, "/caption", req)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan CaptionChunk)
+	go streamSSE(ctx, body, chunks, func(data []byte, chunk *CaptionChunk) bool {
+		if err := json.Unmarshal(data, chunk); err != nil {
+			return false
+		}
+		return true
+	}, func(chunk CaptionChunk) bool { return chunk.Completed })
+
+	return chunks, nil
+}
+
+// QueryStream streams an answer about source from the hosted API, returning
+// a channel of tokens as they arrive. The channel is closed once the stream
+// completes, the context is canceled, or an unrecoverable read error occurs.
+func (b *HTTPBackend) QueryStream(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error) {
+	req := QueryRequest{
+		Image:    source.encoded(),
+		Question: question,
+		Stream:   true,
+	}
+
+	body, err := b.sendStreamingRequest(ctx, "/query", req)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan QueryChunk)
+	go streamSSE(ctx, body, chunks, func(data []byte, chunk *QueryChunk) bool {
+		if err := json.Unmarshal(data, chunk); err != nil {
+			return false
+		}
+		return true
+	}, func(chunk QueryChunk) bool { return chunk.Completed })
+
+	return chunks, nil
+}
+
+// CaptionStream generates an image caption, returning a channel of tokens as
+// they stream in from the configured Backend. The channel is closed once
+// the stream completes, the context is canceled, or an unrecoverable read
+// error occurs.
+func (c *MoondreamClient) CaptionStream(ctx context.Context, imagePath string, length string) (<-chan CaptionChunk, error) {
+	source, err := ImageFromFile(imagePath)
+	if err != nil {
+		return nil, err
+	}
+	return c.CaptionStreamImage(ctx, source, length)
+}
+
+// CaptionStreamImage generates a streamed caption for an ImageSource,
+// letting callers supply images from memory, an io.Reader, or a URL instead
+// of a file path. Like CaptionImage, it is routed through c.backend, so
+// WithBackend (e.g. a LocalBackend) applies to streaming calls too.
+func (c *MoondreamClient) CaptionStreamImage(ctx context.Context, source ImageSource, length string) (<-chan CaptionChunk, error) {
+	return c.backend.CaptionStream(ctx, source, length)
+}
+
+// QueryStream asks a question about an image, returning a channel of tokens
+// as they stream in from the configured Backend. The channel is closed once
+// the stream completes, the context is canceled, or an unrecoverable read
+// error occurs.
+func (c *MoondreamClient) QueryStream(ctx context.Context, imagePath string, question string) (<-chan QueryChunk, error) {
+	source, err := ImageFromFile(imagePath)
+	if err != nil {
+		return nil, err
+	}
+	return c.QueryStreamImage(ctx, source, question)
+}
+
+// QueryStreamImage asks a streamed question about an ImageSource, letting
+// callers supply images from memory, an io.Reader, or a URL instead of a
+// file path. Like QueryImage, it is routed through c.backend, so
+// WithBackend (e.g. a LocalBackend) applies to streaming calls too.
+func (c *MoondreamClient) QueryStreamImage(ctx context.Context, source ImageSource, question string) (<-chan QueryChunk, error) {
+	return c.backend.QueryStream(ctx, source, question)
+}
+
+// streamSSE reads Server-Sent Event frames from body, decoding each one with
+// decode and pushing the result onto out. It stops on the terminating
+// "[DONE]" sentinel, when done reports a chunk as the final one, when ctx is
+// canceled, or when the body is exhausted - closing both out and body before
+// returning.
+func streamSSE[T any](ctx context.Context, body io.ReadCloser, out chan<- T, decode func(data []byte, chunk *T) bool, done func(T) bool) {
+	defer close(out)
+	defer body.Close()
+
+	reader := bufio.NewReader(body)
+	var frame strings.Builder
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		line, err := reader.ReadString('\n')
+		frame.WriteString(line)
+
+		if strings.HasSuffix(frame.String(), "\n\n") || strings.HasSuffix(frame.String(), "\r\n\r\n") {
+			data := extractSSEData(frame.String())
+			frame.Reset()
+
+			if data == "" {
+				if err != nil {
+					return
+				}
+				continue
+			}
+			if data == doneSentinel {
+				return
+			}
+
+			var chunk T
+			if !decode([]byte(data), &chunk) {
+				if err != nil {
+					return
+				}
+				continue
+			}
+
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				return
+			}
+
+			if done(chunk) {
+				return
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// extractSSEData collects the "data:" lines of a single SSE frame, joining
+// multi-line payloads with newlines per the SSE spec.
+func extractSSEData(frame string) string {
+	var lines []string
+	for _, line := range strings.Split(frame, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if rest, ok := strings.CutPrefix(line, "data:"); ok {
+			lines = append(lines, strings.TrimPrefix(rest, " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,122 @@
+package moondream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCaptionStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Expected Accept: text/event-stream, got %s", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		for _, tok := range []string{"A ", "cat ", "sitting."} {
+			fmt.Fprintf(w, "data: {\"chunk\": %q, \"completed\": false}\n\n", tok)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		fmt.Fprintf(w, "data: %s\n\n", doneSentinel)
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	tmpfile, err := os.CreateTemp("", "test-*.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte("test image data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := client.CaptionStream(context.Background(), tmpfile.Name(), "long")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	for chunk := range chunks {
+		got += chunk.Chunk
+	}
+
+	if want := "A cat sitting."; got != want {
+		t.Errorf("Expected caption %q, got %q", want, got)
+	}
+}
+
+func TestCaptionStreamImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"chunk\": \"hi\", \"completed\": false}\n\n")
+		fmt.Fprintf(w, "data: %s\n\n", doneSentinel)
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	chunks, err := client.CaptionStreamImage(context.Background(), ImageFromBytes([]byte("test image data")), "long")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	for chunk := range chunks {
+		got += chunk.Chunk
+	}
+
+	if want := "hi"; got != want {
+		t.Errorf("Expected caption %q, got %q", want, got)
+	}
+}
+
+func TestCaptionStreamContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		fmt.Fprint(w, "data: {\"chunk\": \"hello\", \"completed\": false}\n\n")
+		if flusher != nil {
+			flusher.Flush()
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewMoondreamClient("test-api-key", WithBaseURL(server.URL))
+
+	tmpfile, err := os.CreateTemp("", "test-*.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.Write([]byte("test image data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chunks, err := client.CaptionStream(ctx, tmpfile.Name(), "long")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	<-chunks
+	cancel()
+
+	for range chunks {
+		// drain until the goroutine observes cancellation and closes the channel
+	}
+}
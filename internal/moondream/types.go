@@ -23,10 +23,11 @@ func (e *APIError) Error() string {
 
 // ClientConfig represents the configuration for the Moondream client
 type ClientConfig struct {
-	Timeout    time.Duration
-	BaseURL    string
-	MaxRetries int
-	RetryDelay time.Duration
+	Timeout     time.Duration
+	BaseURL     string
+	MaxRetries  int
+	RetryDelay  time.Duration
+	RetryPolicy RetryPolicy
 }
 
 // ClientOption represents a function that modifies the client configuration
@@ -36,6 +37,7 @@ type ClientOption func(*MoondreamClient)
 type CaptionRequest struct {
 	Image  string `json:"image_url"`
 	Length string `json:"length"`
+	Stream bool   `json:"stream,omitempty"`
 }
 
 // CaptionResponse represents the response from a caption request
@@ -47,6 +49,7 @@ type CaptionResponse struct {
 type QueryRequest struct {
 	Image    string `json:"image_url"`
 	Question string `json:"question"`
+	Stream   bool   `json:"stream,omitempty"`
 }
 
 // QueryResponse represents the response from a query request
@@ -62,7 +65,7 @@ type DetectRequest struct {
 
 // DetectResponse represents the response from a detect request
 type DetectResponse struct {
-	BoundingBoxes []map[string]float64 `json:"objects"`
+	BoundingBoxes []BoundingBox `json:"objects"`
 }
 
 // PointRequest represents a request to point at objects in an image
@@ -73,5 +76,5 @@ type PointRequest struct {
 
 // PointResponse represents the response from a point request
 type PointResponse struct {
-	Points []map[string]float64 `json:"points"`
+	Points []Point `json:"points"`
 }